@@ -0,0 +1,304 @@
+// Package cmd implements one-shot CLI subcommands (add, list, complete,
+// delete, export, import, edit) for scripting against a todolist.TodoList, as
+// an alternative to the interactive menu.
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IsuruSankhajith/Golang-SwiftTodo/todolist"
+)
+
+// CmdFlags dispatches one-shot CLI subcommands (add, list, complete, delete,
+// export, import, edit) against a shared TodoList, for use from shell scripts
+// and pipelines. Interactive mode is used instead when no subcommand is given.
+type CmdFlags struct {
+	TodoList *todolist.TodoList
+	Filename string
+}
+
+// NewCmdFlags creates a CmdFlags dispatcher backed by todoList, persisting
+// changes to filename after every command that mutates the list.
+func NewCmdFlags(todoList *todolist.TodoList, filename string) *CmdFlags {
+	return &CmdFlags{TodoList: todoList, Filename: filename}
+}
+
+// Run parses args (typically os.Args[1:]) and executes the matching
+// subcommand, returning the process exit code.
+func (c *CmdFlags) Run(args []string) int {
+	if len(args) == 0 {
+		fmt.Println("Usage: todo <add|list|complete|delete|export|import|edit> [options]")
+		return 1
+	}
+	switch args[0] {
+	case "add":
+		return c.runAdd(args[1:])
+	case "list":
+		return c.runList(args[1:])
+	case "complete":
+		return c.runComplete(args[1:])
+	case "delete":
+		return c.runDelete(args[1:])
+	case "export":
+		return c.runExport(args[1:])
+	case "import":
+		return c.runImport(args[1:])
+	case "edit":
+		return c.runEdit(args[1:])
+	default:
+		fmt.Printf("Unknown command: %s\n", args[0])
+		return 1
+	}
+}
+
+// save persists the TodoList and reports a non-zero exit code on failure.
+func (c *CmdFlags) save() int {
+	if err := c.TodoList.SaveToFile(c.Filename); err != nil {
+		fmt.Println("Error saving file:", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *CmdFlags) runAdd(args []string) int {
+	due, args := extractStringFlag(args, "due")
+	priority, args := extractStringFlag(args, "priority")
+	if len(args) == 0 {
+		fmt.Println("Usage: todo add <title> [--due YYYY-MM-DD] [--priority A]")
+		return 1
+	}
+	title := strings.Join(args, " ")
+	id := c.TodoList.CreateTodo(title)
+
+	if due != "" || priority != "" {
+		var dueDate *time.Time
+		if due != "" {
+			d, err := time.Parse(todolist.TodoTxtDateFormat, due)
+			if err != nil {
+				fmt.Println("Invalid due date:", err)
+				return 1
+			}
+			dueDate = &d
+		}
+		if err := c.TodoList.SetPriorityAndDueDate(id, strings.ToUpper(priority), dueDate); err != nil {
+			fmt.Println("Error updating to-do:", err)
+			return 1
+		}
+	}
+
+	return c.save()
+}
+
+func (c *CmdFlags) runList(args []string) int {
+	contextFilter, args := extractStringFlag(args, "context")
+	project, args := extractStringFlag(args, "project")
+	jsonOut, args := extractBoolFlag(args, "json")
+	recurringOnly, _ := extractBoolFlag(args, "recurring")
+
+	todos := c.TodoList.AllTodos()
+	if contextFilter != "" {
+		todos = filterTodos(todos, func(todo todolist.Todo) bool {
+			return containsString(todo.Contexts, strings.TrimPrefix(contextFilter, "@"))
+		})
+	}
+	if project != "" {
+		todos = filterTodos(todos, func(todo todolist.Todo) bool {
+			return containsString(todo.Projects, strings.TrimPrefix(project, "+"))
+		})
+	}
+	if recurringOnly {
+		todos = filterTodos(todos, func(todo todolist.Todo) bool {
+			return todo.Recurrence != ""
+		})
+	}
+
+	if jsonOut {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(todos); err != nil {
+			fmt.Println("Error encoding JSON:", err)
+			return 1
+		}
+		return 0
+	}
+
+	printTodoTable(todos)
+	return 0
+}
+
+func (c *CmdFlags) runComplete(args []string) int {
+	if len(args) != 1 {
+		fmt.Println("Usage: todo complete <id>")
+		return 1
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Println("Invalid ID:", args[0])
+		return 1
+	}
+	c.TodoList.UpdateTodo(id, "", true)
+	return c.save()
+}
+
+func (c *CmdFlags) runDelete(args []string) int {
+	if len(args) != 1 {
+		fmt.Println("Usage: todo delete <id>")
+		return 1
+	}
+	id, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Println("Invalid ID:", args[0])
+		return 1
+	}
+	c.TodoList.DeleteTodo(id)
+	return c.save()
+}
+
+func (c *CmdFlags) runExport(args []string) int {
+	format, args := extractStringFlag(args, "format")
+	if format == "" {
+		format = "json"
+	}
+	if len(args) != 1 {
+		fmt.Println("Usage: todo export --format <json|todotxt> <file>")
+		return 1
+	}
+	outFile := args[0]
+
+	var err error
+	switch format {
+	case "todotxt":
+		err = c.TodoList.SaveToTodoTxt(outFile)
+	case "json":
+		err = c.TodoList.SaveToFile(outFile)
+	default:
+		fmt.Println("Unknown export format:", format)
+		return 1
+	}
+	if err != nil {
+		fmt.Println("Error exporting:", err)
+		return 1
+	}
+	return 0
+}
+
+func (c *CmdFlags) runImport(args []string) int {
+	format, args := extractStringFlag(args, "format")
+	if format == "" {
+		format = "json"
+	}
+	if len(args) != 1 {
+		fmt.Println("Usage: todo import --format <json|todotxt> <file>")
+		return 1
+	}
+	inFile := args[0]
+
+	var err error
+	switch format {
+	case "todotxt":
+		err = c.TodoList.LoadFromTodoTxt(inFile)
+	case "json":
+		err = c.TodoList.LoadFromFile(inFile)
+	default:
+		fmt.Println("Unknown import format:", format)
+		return 1
+	}
+	if err != nil {
+		fmt.Println("Error importing:", err)
+		return 1
+	}
+	return c.save()
+}
+
+func (c *CmdFlags) runEdit(args []string) int {
+	if len(args) != 0 {
+		fmt.Println("Usage: todo edit")
+		return 1
+	}
+	if err := c.TodoList.EditExternal(c.Filename); err != nil {
+		fmt.Println("Error editing externally:", err)
+		return 1
+	}
+	return c.save()
+}
+
+// extractStringFlag pulls "--name value" out of args (wherever it appears)
+// and returns the value and the remaining arguments. It returns "" if the
+// flag isn't present.
+func extractStringFlag(args []string, name string) (string, []string) {
+	flagToken := "--" + name
+	rest := make([]string, 0, len(args))
+	value := ""
+	for i := 0; i < len(args); i++ {
+		if args[i] == flagToken {
+			if i+1 < len(args) {
+				value = args[i+1]
+				i++
+			}
+			continue
+		}
+		rest = append(rest, args[i])
+	}
+	return value, rest
+}
+
+// extractBoolFlag pulls the "--name" switch out of args (wherever it
+// appears) and returns whether it was present and the remaining arguments.
+func extractBoolFlag(args []string, name string) (bool, []string) {
+	flagToken := "--" + name
+	rest := make([]string, 0, len(args))
+	found := false
+	for _, a := range args {
+		if a == flagToken {
+			found = true
+			continue
+		}
+		rest = append(rest, a)
+	}
+	return found, rest
+}
+
+// filterTodos returns the todos for which keep returns true.
+func filterTodos(todos []todolist.Todo, keep func(todolist.Todo) bool) []todolist.Todo {
+	var result []todolist.Todo
+	for _, todo := range todos {
+		if keep(todo) {
+			result = append(result, todo)
+		}
+	}
+	return result
+}
+
+// containsString reports whether target is present in values.
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+// printTodoTable prints todos as aligned columns: ID, Title, Status, Due.
+func printTodoTable(todos []todolist.Todo) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTitle\tStatus\tDue")
+	for _, todo := range todos {
+		status := "Incomplete"
+		if todo.Completed {
+			status = "Completed"
+		}
+		due := ""
+		if todo.DueDate != nil {
+			due = todo.DueDate.Format(todolist.TodoTxtDateFormat)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", todo.ID, todo.Title, status, due)
+	}
+	w.Flush()
+}
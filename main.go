@@ -2,164 +2,18 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
 	"strings"
-	"sync"
 	"time"
-)
-
-// Todo represents a single task with a title, completion status, and creation time.
-type Todo struct {
-	ID        int       `json:"id"`
-	Title     string    `json:"title"`
-	Completed bool      `json:"completed"`
-	CreatedAt time.Time `json:"created_at"`
-}
-
-// TodoList is a struct that manages a list of todos and a mutex for thread-safe operations.
-type TodoList struct {
-	todos     []Todo
-	idCounter int
-	mu        sync.Mutex
-	changed   bool // Flag to track if any changes have been made
-}
-
-// CreateTodo adds a new todo to the list.
-func (t *TodoList) CreateTodo(title string) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	t.idCounter++
-	newTodo := Todo{
-		ID:        t.idCounter,
-		Title:     title,
-		Completed: false,
-		CreatedAt: time.Now(),
-	}
-	t.todos = append(t.todos, newTodo)
-	t.changed = true
-	fmt.Println("To-Do added successfully.")
-}
-
-// ListTodos prints all todos in the list.
-func (t *TodoList) ListTodos() {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	if len(t.todos) == 0 {
-		fmt.Println("No To-Dos found.")
-		return
-	}
-	fmt.Println("\nTo-Do List:")
-	for _, todo := range t.todos {
-		status := "Incomplete"
-		if todo.Completed {
-			status = "Completed"
-		}
-		fmt.Printf("ID: %d | Title: %s | Status: %s | Created At: %s\n", todo.ID, todo.Title, status, todo.CreatedAt.Format(time.RFC822))
-	}
-}
-
-// UpdateTodo allows updating a todo's title and completion status.
-func (t *TodoList) UpdateTodo(id int, newTitle string, completed bool) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	for i, todo := range t.todos {
-		if todo.ID == id {
-			if newTitle != "" {
-				t.todos[i].Title = newTitle
-			}
-			t.todos[i].Completed = completed
-			t.changed = true
-			fmt.Println("To-Do updated successfully.")
-			return
-		}
-	}
-	fmt.Println("To-Do not found.")
-}
-
-// DeleteTodo removes a todo from the list by ID.
-func (t *TodoList) DeleteTodo(id int) {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	for i, todo := range t.todos {
-		if todo.ID == id {
-			t.todos = append(t.todos[:i], t.todos[i+1:]...)
-			t.changed = true
-			fmt.Println("To-Do deleted successfully.")
-			return
-		}
-	}
-	fmt.Println("To-Do not found.")
-}
-
-// SaveToFile saves the todos to a file in JSON format.
-func (t *TodoList) SaveToFile(filename string) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	file, err := os.Create(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	err = encoder.Encode(t.todos)
-	if err != nil {
-		return err
-	}
-	fmt.Println("To-Do list saved to file.")
-	t.changed = false // Reset the changed flag after saving
-	return nil
-}
-
-// LoadFromFile loads todos from a file.
-func (t *TodoList) LoadFromFile(filename string) error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&t.todos)
-	if err != nil {
-		return err
-	}
-	fmt.Println("To-Do list loaded from file.")
-	return nil
-}
-
-// AutoSave periodically saves the todos to a file if there are changes.
-func (t *TodoList) AutoSave(filename string, interval time.Duration, done chan bool) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			// Only save if there are changes
-			t.mu.Lock()
-			shouldSave := t.changed
-			t.mu.Unlock()
-
-			if shouldSave {
-				err := t.SaveToFile(filename)
-				if err != nil {
-					fmt.Println("Error saving file:", err)
-				}
-			}
-		case <-done:
-			fmt.Println("Auto-save stopped.")
-			return
-		}
-	}
-}
+	"github.com/IsuruSankhajith/Golang-SwiftTodo/cmd"
+	"github.com/IsuruSankhajith/Golang-SwiftTodo/todolist"
+)
 
 func main() {
-	todoList := &TodoList{}
+	todoList := &todolist.TodoList{}
 	filename := "todos.json"
 
 	// Load from file at the start
@@ -168,9 +22,23 @@ func main() {
 		fmt.Println("Error loading file:", err)
 	}
 
-	// Start auto-saving in a separate goroutine
+	// One-shot CLI mode: "todo add|list|complete|delete|export|edit ..." runs a single
+	// command against the shared TodoList and exits. With no arguments, fall
+	// through to the interactive menu below.
+	if len(os.Args) > 1 {
+		cmdFlags := cmd.NewCmdFlags(todoList, filename)
+		os.Exit(cmdFlags.Run(os.Args[1:]))
+	}
+
+	// Configure CalDAV sync from the environment, if set.
+	var syncer *todolist.CalDAVSyncer
+	if caldavURL := os.Getenv("CALDAV_URL"); caldavURL != "" {
+		syncer = todolist.NewCalDAVSyncer(caldavURL, os.Getenv("CALDAV_CALENDAR_PATH"), os.Getenv("CALDAV_USERNAME"), os.Getenv("CALDAV_PASSWORD"))
+	}
+
+	// Start auto-saving (and, if configured, CalDAV syncing) in a separate goroutine
 	done := make(chan bool)
-	go todoList.AutoSave(filename, 10*time.Second, done)
+	go todoList.AutoSave(filename, 10*time.Second, done, syncer)
 
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Println("Enhanced To-Do Application with Auto-Save")
@@ -184,6 +52,8 @@ func main() {
 		fmt.Println("3. Update To-Do")
 		fmt.Println("4. Delete To-Do")
 		fmt.Println("5. Exit")
+		fmt.Println("6. Sync with CalDAV")
+		fmt.Println("7. Edit Externally")
 		fmt.Print("Enter your choice: ")
 
 		choice, _ := reader.ReadString('\n')
@@ -237,6 +107,18 @@ func main() {
 			fmt.Println("Exiting...")
 			done <- true // Signal the goroutine to stop auto-saving
 			return
+		case "6":
+			if syncer == nil {
+				fmt.Println("CalDAV sync is not configured. Set CALDAV_URL, CALDAV_USERNAME, CALDAV_PASSWORD, and CALDAV_CALENDAR_PATH.")
+				continue
+			}
+			if err := syncer.Sync(context.Background(), todoList); err != nil {
+				fmt.Println("Error syncing with CalDAV:", err)
+			}
+		case "7":
+			if err := todoList.EditExternal(filename); err != nil {
+				fmt.Println("Error editing externally:", err)
+			}
 		default:
 			fmt.Println("Invalid choice. Please try again.")
 		}
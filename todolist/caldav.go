@@ -0,0 +1,312 @@
+package todolist
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const icsDateFormat = "20060102T150405Z"
+
+// CalDAVSyncer bidirectionally synchronizes a TodoList with a remote CalDAV
+// server that stores tasks as iCalendar VTODO components.
+type CalDAVSyncer struct {
+	BaseURL      string
+	CalendarPath string
+	Username     string
+	Password     string
+	Client       *http.Client
+}
+
+// NewCalDAVSyncer creates a CalDAVSyncer for the given server and calendar.
+func NewCalDAVSyncer(baseURL, calendarPath, username, password string) *CalDAVSyncer {
+	return &CalDAVSyncer{
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		CalendarPath: strings.Trim(calendarPath, "/"),
+		Username:     username,
+		Password:     password,
+		Client:       &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// objectURL returns the CalDAV object URL for a todo with the given UID.
+func (s *CalDAVSyncer) objectURL(uid string) string {
+	return fmt.Sprintf("%s/%s/%s.ics", s.BaseURL, s.CalendarPath, uid)
+}
+
+// calendarURL returns the collection URL used for PROPFIND/REPORT requests.
+func (s *CalDAVSyncer) calendarURL() string {
+	return fmt.Sprintf("%s/%s/", s.BaseURL, s.CalendarPath)
+}
+
+func (s *CalDAVSyncer) do(ctx context.Context, method, url, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.SetBasicAuth(s.Username, s.Password)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return s.Client.Do(req)
+}
+
+// Push uploads every local todo to the CalDAV server and propagates local
+// deletions, assigning a UID to any todo that doesn't already have one.
+func (s *CalDAVSyncer) Push(ctx context.Context, t *TodoList) error {
+	t.mu.Lock()
+	for i := range t.todos {
+		if t.todos[i].UID == "" {
+			t.todos[i].UID = generateUID()
+			t.changed = true
+		}
+	}
+	todos := make([]Todo, len(t.todos))
+	copy(todos, t.todos)
+	deletedUIDs := t.deletedUIDs
+	t.deletedUIDs = nil
+	t.mu.Unlock()
+
+	for _, todo := range todos {
+		resp, err := s.do(ctx, http.MethodPut, s.objectURL(todo.UID), "text/calendar; charset=utf-8", strings.NewReader(todoToVTODO(todo)))
+		if err != nil {
+			return fmt.Errorf("caldav push %s: %w", todo.UID, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 {
+			return fmt.Errorf("caldav push %s: unexpected status %s", todo.UID, resp.Status)
+		}
+	}
+
+	for _, uid := range deletedUIDs {
+		resp, err := s.do(ctx, http.MethodDelete, s.objectURL(uid), "", nil)
+		if err != nil {
+			return fmt.Errorf("caldav delete %s: %w", uid, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 300 && resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("caldav delete %s: unexpected status %s", uid, resp.Status)
+		}
+	}
+
+	fmt.Println("Pushed to-dos to CalDAV server.")
+	return nil
+}
+
+// davMultistatus mirrors the parts of a CalDAV/WebDAV multistatus response we need.
+type davMultistatus struct {
+	XMLName   xml.Name `xml:"multistatus"`
+	Responses []struct {
+		Href string `xml:"href"`
+	} `xml:"response"`
+}
+
+// Pull lists VTODO objects on the server, fetches each one, and merges it
+// into the local TodoList by UID, preferring whichever side was modified
+// most recently. Objects whose UID is still pending local deletion (queued
+// in deletedUIDs for the next Push) are skipped, so a delete that hasn't
+// reached the server yet isn't resurrected as a new local todo.
+func (s *CalDAVSyncer) Pull(ctx context.Context, t *TodoList) error {
+	propfindBody := `<?xml version="1.0" encoding="utf-8" ?>
+<D:propfind xmlns:D="DAV:"><D:prop><D:getetag/></D:prop></D:propfind>`
+	req, err := http.NewRequestWithContext(ctx, "PROPFIND", s.calendarURL(), strings.NewReader(propfindBody))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.Username, s.Password)
+	req.Header.Set("Content-Type", "application/xml; charset=utf-8")
+	req.Header.Set("Depth", "1")
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("caldav propfind: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("caldav propfind: unexpected status %s", resp.Status)
+	}
+
+	var ms davMultistatus
+	if err := xml.NewDecoder(resp.Body).Decode(&ms); err != nil {
+		return fmt.Errorf("caldav propfind: %w", err)
+	}
+
+	for _, r := range ms.Responses {
+		if !strings.HasSuffix(r.Href, ".ics") {
+			continue
+		}
+		href := r.Href
+		if !strings.HasPrefix(href, "http://") && !strings.HasPrefix(href, "https://") {
+			href = s.BaseURL + "/" + strings.TrimLeft(href, "/")
+		}
+		getResp, err := s.do(ctx, http.MethodGet, href, "", nil)
+		if err != nil {
+			return fmt.Errorf("caldav get %s: %w", href, err)
+		}
+		data, err := io.ReadAll(getResp.Body)
+		getResp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("caldav get %s: %w", href, err)
+		}
+		remote := parseVTODO(string(data))
+		if remote.UID == "" {
+			continue
+		}
+		if t.isPendingDeletion(remote.UID) {
+			continue
+		}
+		mergeRemoteTodo(t, remote)
+	}
+
+	fmt.Println("Pulled to-dos from CalDAV server.")
+	return nil
+}
+
+// isPendingDeletion reports whether uid is queued in deletedUIDs, waiting
+// for the next Push to propagate its deletion to the server.
+func (t *TodoList) isPendingDeletion(uid string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, deleted := range t.deletedUIDs {
+		if deleted == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeRemoteTodo merges a remote todo into the list by UID. When the remote
+// copy is newer, its title, completion, and timestamps win, but fields the
+// CalDAV VTODO format can't carry (Priority, Projects, Contexts, Tags,
+// DueDate, Recurrence, RecurrenceN) are kept from the local copy rather than
+// being zeroed out.
+func mergeRemoteTodo(t *TodoList, remote Todo) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.todos {
+		if t.todos[i].UID == remote.UID {
+			if remote.UpdatedAt.After(t.todos[i].UpdatedAt) {
+				local := t.todos[i]
+				remote.ID = local.ID
+				remote.Priority = local.Priority
+				remote.Projects = local.Projects
+				remote.Contexts = local.Contexts
+				remote.Tags = local.Tags
+				remote.DueDate = local.DueDate
+				remote.Recurrence = local.Recurrence
+				remote.RecurrenceN = local.RecurrenceN
+				t.todos[i] = remote
+				t.changed = true
+			}
+			return
+		}
+	}
+	t.idCounter++
+	remote.ID = t.idCounter
+	t.todos = append(t.todos, remote)
+	t.changed = true
+}
+
+// Sync pulls remote changes, merges them locally, then pushes the result
+// (including any newly assigned UIDs and pending deletions) back up.
+func (s *CalDAVSyncer) Sync(ctx context.Context, t *TodoList) error {
+	if err := s.Pull(ctx, t); err != nil {
+		return err
+	}
+	if err := s.Push(ctx, t); err != nil {
+		return err
+	}
+	fmt.Println("Synced to-dos with CalDAV server.")
+	return nil
+}
+
+// todoToVTODO serializes a Todo into a VCALENDAR containing a single VTODO.
+func todoToVTODO(todo Todo) string {
+	status := "NEEDS-ACTION"
+	if todo.Completed {
+		status = "COMPLETED"
+	}
+	var b strings.Builder
+	b.WriteString("BEGIN:VCALENDAR\r\n")
+	b.WriteString("VERSION:2.0\r\n")
+	b.WriteString("BEGIN:VTODO\r\n")
+	fmt.Fprintf(&b, "UID:%s\r\n", todo.UID)
+	fmt.Fprintf(&b, "SUMMARY:%s\r\n", icsEscape(todo.Title))
+	fmt.Fprintf(&b, "CREATED:%s\r\n", todo.CreatedAt.UTC().Format(icsDateFormat))
+	fmt.Fprintf(&b, "LAST-MODIFIED:%s\r\n", todo.UpdatedAt.UTC().Format(icsDateFormat))
+	if todo.Completed && todo.CompletedAt != nil {
+		fmt.Fprintf(&b, "COMPLETED:%s\r\n", todo.CompletedAt.UTC().Format(icsDateFormat))
+	}
+	fmt.Fprintf(&b, "STATUS:%s\r\n", status)
+	b.WriteString("END:VTODO\r\n")
+	b.WriteString("END:VCALENDAR\r\n")
+	return b.String()
+}
+
+// parseVTODO parses the VTODO component out of an iCalendar document.
+func parseVTODO(data string) Todo {
+	var todo Todo
+	inVTodo := false
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case line == "BEGIN:VTODO":
+			inVTodo = true
+			continue
+		case line == "END:VTODO":
+			inVTodo = false
+			continue
+		case !inVTodo:
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "UID":
+			todo.UID = value
+		case "SUMMARY":
+			todo.Title = icsUnescape(value)
+		case "CREATED":
+			if ts, err := time.Parse(icsDateFormat, value); err == nil {
+				todo.CreatedAt = ts
+			}
+		case "LAST-MODIFIED":
+			if ts, err := time.Parse(icsDateFormat, value); err == nil {
+				todo.UpdatedAt = ts
+			}
+		case "COMPLETED":
+			if ts, err := time.Parse(icsDateFormat, value); err == nil {
+				todo.CompletedAt = &ts
+			}
+		case "STATUS":
+			todo.Completed = value == "COMPLETED"
+		}
+	}
+	return todo
+}
+
+func icsEscape(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `,`, `\,`, `;`, `\;`, "\n", `\n`)
+	return replacer.Replace(s)
+}
+
+func icsUnescape(s string) string {
+	replacer := strings.NewReplacer(`\,`, `,`, `\;`, `;`, `\n`, "\n", `\\`, `\`)
+	return replacer.Replace(s)
+}
+
+// generateUID returns a random UUID (v4) used to identify a todo on the
+// CalDAV server.
+func generateUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,146 @@
+package todolist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRule is a parsed subset of iCalendar RRULE syntax:
+// FREQ=DAILY|WEEKLY|MONTHLY;INTERVAL=n;BYDAY=MO,WE;COUNT=n;UNTIL=YYYYMMDD
+type RRule struct {
+	Freq     string     // DAILY, WEEKLY, or MONTHLY
+	Interval int        // defaults to 1
+	ByDay    []string   // two-letter weekday codes: MO, TU, WE, TH, FR, SA, SU
+	Count    int        // 0 means unbounded
+	Until    *time.Time // nil means unbounded
+}
+
+var rruleWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+// ParseRRule parses a semicolon-separated RRULE string into an RRule.
+func ParseRRule(s string) (*RRule, error) {
+	rule := &RRule{Interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("recurrence: invalid token %q", part)
+		}
+		switch key {
+		case "FREQ":
+			if value != "DAILY" && value != "WEEKLY" && value != "MONTHLY" {
+				return nil, fmt.Errorf("recurrence: unsupported FREQ %q", value)
+			}
+			rule.Freq = value
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("recurrence: invalid INTERVAL %q", value)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				if _, ok := rruleWeekdays[day]; !ok {
+					return nil, fmt.Errorf("recurrence: invalid BYDAY %q", day)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("recurrence: invalid COUNT %q", value)
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := time.Parse("20060102", value)
+			if err != nil {
+				return nil, fmt.Errorf("recurrence: invalid UNTIL %q", value)
+			}
+			rule.Until = &until
+		default:
+			return nil, fmt.Errorf("recurrence: unknown key %q", key)
+		}
+	}
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("recurrence: missing FREQ")
+	}
+	return rule, nil
+}
+
+// Next returns the next occurrence strictly after "after". For DAILY and
+// MONTHLY it advances by Interval days/months, then snaps forward to the
+// next BYDAY match (if any). For WEEKLY with BYDAY it walks day-by-day
+// within the current week, then skips ahead by Interval weeks once that
+// week is exhausted.
+func (r *RRule) Next(after time.Time) time.Time {
+	switch r.Freq {
+	case "WEEKLY":
+		if len(r.ByDay) > 0 {
+			return r.nextWeeklyByDay(after)
+		}
+		return after.AddDate(0, 0, 7*r.Interval)
+	case "MONTHLY":
+		next := after.AddDate(0, r.Interval, 0)
+		return r.snapToByDay(next)
+	default: // DAILY
+		next := after.AddDate(0, 0, r.Interval)
+		return r.snapToByDay(next)
+	}
+}
+
+// snapToByDay advances from until its weekday matches ByDay, scanning at
+// most a week forward. It returns from unchanged when ByDay is empty.
+func (r *RRule) snapToByDay(from time.Time) time.Time {
+	if len(r.ByDay) == 0 {
+		return from
+	}
+	for i := 0; i < 7; i++ {
+		if r.matchesByDay(from.Weekday()) {
+			return from
+		}
+		from = from.AddDate(0, 0, 1)
+	}
+	return from
+}
+
+// nextWeeklyByDay finds the next BYDAY match after "after", staying within
+// the remainder of the current week first and only skipping Interval-1 extra
+// weeks once that week has no remaining match, so INTERVAL>1 still advances
+// by 7*Interval days instead of finding a same-week match every time.
+func (r *RRule) nextWeeklyByDay(after time.Time) time.Time {
+	daysSinceMonday := (int(after.Weekday()) + 6) % 7
+	weekStart := after.AddDate(0, 0, -daysSinceMonday)
+	weekEnd := weekStart.AddDate(0, 0, 7)
+
+	cursor := after.AddDate(0, 0, 1)
+	for cursor.Before(weekEnd) {
+		if r.matchesByDay(cursor.Weekday()) {
+			return cursor
+		}
+		cursor = cursor.AddDate(0, 0, 1)
+	}
+	cursor = weekStart.AddDate(0, 0, 7*r.Interval)
+	for i := 0; i < 7; i++ {
+		if r.matchesByDay(cursor.Weekday()) {
+			return cursor
+		}
+		cursor = cursor.AddDate(0, 0, 1)
+	}
+	return cursor
+}
+
+func (r *RRule) matchesByDay(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if rruleWeekdays[day] == weekday {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package todolist
+
+import (
+	"testing"
+	"time"
+)
+
+func mustParseDate(t *testing.T, s string) time.Time {
+	t.Helper()
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		t.Fatalf("parsing date %q: %v", s, err)
+	}
+	return d
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{"daily", "FREQ=DAILY", false},
+		{"weekly with byday", "FREQ=WEEKLY;BYDAY=MO,WE", false},
+		{"monthly with interval", "FREQ=MONTHLY;INTERVAL=2", false},
+		{"with count", "FREQ=DAILY;COUNT=5", false},
+		{"with until", "FREQ=DAILY;UNTIL=20260101", false},
+		{"missing freq", "INTERVAL=2", true},
+		{"unsupported freq", "FREQ=YEARLY", true},
+		{"invalid interval", "FREQ=DAILY;INTERVAL=0", true},
+		{"invalid byday", "FREQ=WEEKLY;BYDAY=XX", true},
+		{"invalid count", "FREQ=DAILY;COUNT=-1", true},
+		{"invalid until", "FREQ=DAILY;UNTIL=not-a-date", true},
+		{"unknown key", "FREQ=DAILY;FOO=BAR", true},
+		{"malformed token", "FREQ", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRRule(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRRuleNextDaily(t *testing.T) {
+	rule, err := ParseRRule("FREQ=DAILY;INTERVAL=3")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	after := mustParseDate(t, "2026-01-01") // Thursday
+	got := rule.Next(after)
+	want := mustParseDate(t, "2026-01-04")
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", after, got, want)
+	}
+}
+
+func TestRRuleNextMonthlyWithByDay(t *testing.T) {
+	rule, err := ParseRRule("FREQ=MONTHLY;BYDAY=MO")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	// 2026-01-01 is a Thursday; one month later is 2026-02-01 (Sunday), which
+	// should snap forward to the next Monday.
+	after := mustParseDate(t, "2026-01-01")
+	got := rule.Next(after)
+	want := mustParseDate(t, "2026-02-02")
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", after, got, want)
+	}
+}
+
+// TestRRuleNextWeeklyByDayInterval is a regression test for a bug where
+// WEEKLY;INTERVAL>1 with BYDAY always found a same-week match instead of
+// skipping Interval-1 extra weeks.
+func TestRRuleNextWeeklyByDayInterval(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	// 2026-01-07 is a Wednesday in week 1 (week starting 2026-01-05). The
+	// remainder of that week has no further MO/WE match, so the next
+	// occurrence should be in the week starting 2*7 = 14 days later, landing
+	// on Monday 2026-01-19 rather than the following Wednesday a week away.
+	after := mustParseDate(t, "2026-01-07")
+	got := rule.Next(after)
+	want := mustParseDate(t, "2026-01-19")
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", after, got, want)
+	}
+}
+
+func TestRRuleNextWeeklyByDaySameWeek(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	// 2026-01-05 is a Monday; the next BYDAY match within the same week is
+	// Wednesday 2026-01-07.
+	after := mustParseDate(t, "2026-01-05")
+	got := rule.Next(after)
+	want := mustParseDate(t, "2026-01-07")
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", after, got, want)
+	}
+}
+
+func TestRRuleNextWeeklyNoByDay(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;INTERVAL=2")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+	after := mustParseDate(t, "2026-01-05")
+	got := rule.Next(after)
+	want := mustParseDate(t, "2026-01-19")
+	if !got.Equal(want) {
+		t.Errorf("Next(%v) = %v, want %v", after, got, want)
+	}
+}
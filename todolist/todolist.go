@@ -0,0 +1,553 @@
+// Package todolist implements the core to-do list model: in-memory storage,
+// JSON persistence with optimistic concurrency, and the todo.txt/CalDAV/RRULE
+// subsystems built on top of it.
+package todolist
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrListChangedOnDisk is returned by SaveToFile when the file on disk has
+// been modified since it was loaded, to avoid clobbering an external edit.
+var ErrListChangedOnDisk = errors.New("todo: list changed on disk since it was loaded; reload before saving")
+
+// Todo represents a single task with a title, completion status, and creation time.
+type Todo struct {
+	ID          int               `json:"id"`
+	Title       string            `json:"title"`
+	Completed   bool              `json:"completed"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	Priority    string            `json:"priority,omitempty"` // single uppercase letter A-Z, empty when unset
+	Projects    []string          `json:"projects,omitempty"` // todo.txt "+project" tokens, without the leading +
+	Contexts    []string          `json:"contexts,omitempty"` // todo.txt "@context" tokens, without the leading @
+	Tags        map[string]string `json:"tags,omitempty"`     // todo.txt "key:value" tokens
+	DueDate     *time.Time        `json:"due_date,omitempty"` // todo.txt "due:YYYY-MM-DD" tag
+	CompletedAt *time.Time        `json:"completed_at,omitempty"`
+	UID         string            `json:"uid,omitempty"`          // stable identifier used by the CalDAV syncer
+	Recurrence  string            `json:"recurrence,omitempty"`   // RRULE-style rule, e.g. "FREQ=WEEKLY;BYDAY=MO,WE"
+	RecurrenceN int               `json:"recurrence_n,omitempty"` // occurrences already generated for this recurrence chain
+}
+
+// TodoList is a struct that manages a list of todos and a mutex for thread-safe operations.
+type TodoList struct {
+	todos       []Todo
+	idCounter   int
+	mu          sync.Mutex
+	changed     bool         // Flag to track if any changes have been made
+	deletedUIDs []string     // UIDs removed locally, pending propagation to the CalDAV server
+	loadedMTime time.Time    // mtime of the file as of the last successful load/save
+	loadedHash  [32]byte     // SHA-256 of the file contents as of the last successful load/save
+	loadedTodos map[int]Todo // per-ID snapshot as of the last successful load/save, for diffing against in-memory edits
+}
+
+// CreateTodo adds a new todo to the list and returns its ID.
+func (t *TodoList) CreateTodo(title string) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.idCounter++
+	now := time.Now()
+	newTodo := Todo{
+		ID:        t.idCounter,
+		Title:     title,
+		Completed: false,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	t.todos = append(t.todos, newTodo)
+	t.changed = true
+	fmt.Println("To-Do added successfully.")
+	return newTodo.ID
+}
+
+// SetPriorityAndDueDate updates a todo's priority and/or due date in place.
+// Passing an empty priority or a nil due leaves that field unchanged.
+func (t *TodoList) SetPriorityAndDueDate(id int, priority string, due *time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := range t.todos {
+		if t.todos[i].ID == id {
+			if priority != "" {
+				t.todos[i].Priority = priority
+			}
+			if due != nil {
+				t.todos[i].DueDate = due
+			}
+			t.changed = true
+			return nil
+		}
+	}
+	return fmt.Errorf("todo: no such id %d", id)
+}
+
+// ListTodos prints all todos in the list.
+func (t *TodoList) ListTodos() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.todos) == 0 {
+		fmt.Println("No To-Dos found.")
+		return
+	}
+	fmt.Println("\nTo-Do List:")
+	for _, todo := range t.todos {
+		status := "Incomplete"
+		if todo.Completed {
+			status = "Completed"
+		}
+		fmt.Printf("ID: %d | Title: %s | Status: %s | Created At: %s\n", todo.ID, todo.Title, status, todo.CreatedAt.Format(time.RFC822))
+	}
+}
+
+// UpdateTodo allows updating a todo's title and completion status. Marking a
+// recurring todo completed also spawns its next occurrence (see
+// spawnNextOccurrenceLocked).
+func (t *TodoList) UpdateTodo(id int, newTitle string, completed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, todo := range t.todos {
+		if todo.ID == id {
+			if newTitle != "" {
+				t.todos[i].Title = newTitle
+			}
+			wasCompleted := t.todos[i].Completed
+			t.todos[i].Completed = completed
+			t.todos[i].UpdatedAt = time.Now()
+			if completed && !wasCompleted {
+				now := time.Now()
+				t.todos[i].CompletedAt = &now
+				t.spawnNextOccurrenceLocked(t.todos[i])
+			}
+			t.changed = true
+			fmt.Println("To-Do updated successfully.")
+			return
+		}
+	}
+	fmt.Println("To-Do not found.")
+}
+
+// spawnNextOccurrenceLocked appends the next occurrence of a just-completed
+// recurring todo, advancing its due date according to its RRULE-style
+// Recurrence. It is a no-op for non-recurring todos, todos without a due
+// date, or once the rule's COUNT/UNTIL bound has been reached. Callers must
+// hold t.mu.
+func (t *TodoList) spawnNextOccurrenceLocked(completed Todo) {
+	if completed.Recurrence == "" || completed.DueDate == nil {
+		return
+	}
+	rule, err := ParseRRule(completed.Recurrence)
+	if err != nil {
+		fmt.Println("Invalid recurrence rule:", err)
+		return
+	}
+	if rule.Count > 0 && completed.RecurrenceN+1 >= rule.Count {
+		return
+	}
+	next := rule.Next(*completed.DueDate)
+	if rule.Until != nil && next.After(*rule.Until) {
+		return
+	}
+
+	var tags map[string]string
+	if completed.Tags != nil {
+		tags = make(map[string]string, len(completed.Tags))
+		for k, v := range completed.Tags {
+			tags[k] = v
+		}
+	}
+
+	t.idCounter++
+	now := time.Now()
+	t.todos = append(t.todos, Todo{
+		ID:          t.idCounter,
+		Title:       completed.Title,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Priority:    completed.Priority,
+		Projects:    completed.Projects,
+		Contexts:    completed.Contexts,
+		Tags:        tags,
+		DueDate:     &next,
+		Recurrence:  completed.Recurrence,
+		RecurrenceN: completed.RecurrenceN + 1,
+	})
+}
+
+// DeleteTodo removes a todo from the list by ID.
+func (t *TodoList) DeleteTodo(id int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, todo := range t.todos {
+		if todo.ID == id {
+			if todo.UID != "" {
+				t.deletedUIDs = append(t.deletedUIDs, todo.UID)
+			}
+			t.todos = append(t.todos[:i], t.todos[i+1:]...)
+			t.changed = true
+			fmt.Println("To-Do deleted successfully.")
+			return
+		}
+	}
+	fmt.Println("To-Do not found.")
+}
+
+// SaveToFile saves the todos to a file in JSON format. If the file has been
+// loaded before and has since changed on disk (different mtime or contents),
+// SaveToFile refuses to overwrite it and returns ErrListChangedOnDisk.
+func (t *TodoList) SaveToFile(filename string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.loadedMTime.IsZero() {
+		changed, err := t.onDiskChangedLocked(filename)
+		if err != nil {
+			return err
+		}
+		if changed {
+			return ErrListChangedOnDisk
+		}
+	}
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	err = encoder.Encode(t.todos)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "To-Do list saved to file.")
+	t.changed = false // Reset the changed flag after saving
+
+	if info, data, err := statAndRead(filename); err == nil {
+		t.recordLoadedStateLocked(info, data)
+	}
+	return nil
+}
+
+// onDiskChangedLocked reports whether filename's mtime or contents differ
+// from what was recorded at the last successful load/save. Callers must
+// hold t.mu.
+func (t *TodoList) onDiskChangedLocked(filename string) (bool, error) {
+	info, data, err := statAndRead(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !info.ModTime().Equal(t.loadedMTime) {
+		return true, nil
+	}
+	return sha256.Sum256(data) != t.loadedHash, nil
+}
+
+// statAndRead stats and reads filename, returning both in one call so
+// callers can compare mtime and contents against a previously recorded state.
+func statAndRead(filename string) (os.FileInfo, []byte, error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, data, nil
+}
+
+// recordLoadedStateLocked snapshots info/data and the current todos as the
+// last-known-loaded state, so a later reconcileExternalEdit can tell whether
+// an in-memory todo has actually been modified since it was loaded. Callers
+// must hold t.mu.
+func (t *TodoList) recordLoadedStateLocked(info os.FileInfo, data []byte) {
+	t.loadedMTime = info.ModTime()
+	t.loadedHash = sha256.Sum256(data)
+	t.loadedTodos = make(map[int]Todo, len(t.todos))
+	for _, todo := range t.todos {
+		t.loadedTodos[todo.ID] = todo
+	}
+}
+
+// LoadFromFile loads todos from a file, recording its mtime and content hash
+// so later saves and reloads can detect out-of-band changes.
+func (t *TodoList) LoadFromFile(filename string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	info, data, err := statAndRead(filename)
+	if err != nil {
+		return err
+	}
+	var todos []Todo
+	if err := json.Unmarshal(data, &todos); err != nil {
+		return err
+	}
+	t.todos = todos
+	for _, todo := range todos {
+		if todo.ID > t.idCounter {
+			t.idCounter = todo.ID
+		}
+	}
+	t.recordLoadedStateLocked(info, data)
+	fmt.Fprintln(os.Stderr, "To-Do list loaded from file.")
+	return nil
+}
+
+// Reload re-reads filename if it has changed on disk since the last
+// load/save, picking up out-of-band edits. It is a no-op when the file is
+// unchanged, and is intended to be called periodically (e.g. from the
+// auto-save loop) rather than before every read.
+func (t *TodoList) Reload(filename string) error {
+	t.mu.Lock()
+	changed, err := t.onDiskChangedLocked(filename)
+	if err != nil || !changed {
+		t.mu.Unlock()
+		return err
+	}
+	t.mu.Unlock()
+
+	info, data, err := statAndRead(filename)
+	if err != nil {
+		return err
+	}
+	var todos []Todo
+	if err := json.Unmarshal(data, &todos); err != nil {
+		return fmt.Errorf("reload: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.todos = todos
+	for _, todo := range todos {
+		if todo.ID > t.idCounter {
+			t.idCounter = todo.ID
+		}
+	}
+	t.recordLoadedStateLocked(info, data)
+	fmt.Println("To-Do list reloaded from file (changed on disk).")
+	return nil
+}
+
+// EditExternal launches $EDITOR (falling back to vi) on filename, then
+// reconciles the edited file with the in-memory list: todos present in both
+// take the on-disk version, todos new on disk are appended, and todos
+// deleted on disk are dropped silently unless they were also modified in
+// memory since the last load, in which case the user is asked whether to
+// keep them (see reconcileExternalEdit).
+func (t *TodoList) EditExternal(filename string) error {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	cmd := exec.Command(editor, filename)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("launching editor: %w", err)
+	}
+
+	return t.reconcileExternalEdit(filename)
+}
+
+// reconcileExternalEdit merges the file at filename (as left by an external
+// editor) back into the in-memory list. See EditExternal for the merge rules.
+// A todo missing from the edited file is only surfaced for confirmation if
+// it was modified in memory since the last load; one that's unchanged since
+// then is assumed to have been intentionally deleted and is dropped without
+// prompting.
+func (t *TodoList) reconcileExternalEdit(filename string) error {
+	info, data, err := statAndRead(filename)
+	if err != nil {
+		return err
+	}
+	var onDisk []Todo
+	if err := json.Unmarshal(data, &onDisk); err != nil {
+		return fmt.Errorf("parsing edited file: %w", err)
+	}
+	onDiskByID := make(map[int]Todo, len(onDisk))
+	for _, todo := range onDisk {
+		onDiskByID[todo.ID] = todo
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	reader := bufio.NewReader(os.Stdin)
+	var merged []Todo
+	for _, local := range t.todos {
+		if onDiskTodo, ok := onDiskByID[local.ID]; ok {
+			merged = append(merged, onDiskTodo)
+			delete(onDiskByID, local.ID)
+			continue
+		}
+		if loaded, ok := t.loadedTodos[local.ID]; ok && reflect.DeepEqual(local, loaded) {
+			continue
+		}
+		fmt.Printf("To-Do %d (%q) was deleted on disk but modified in memory. Keep it? (yes/no): ", local.ID, local.Title)
+		answer, _ := reader.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(answer)) == "yes" {
+			merged = append(merged, local)
+		}
+	}
+
+	var added []Todo
+	for _, todo := range onDisk {
+		if _, isNew := onDiskByID[todo.ID]; isNew {
+			added = append(added, todo)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].ID < added[j].ID })
+	merged = append(merged, added...)
+
+	t.todos = merged
+	for _, todo := range merged {
+		if todo.ID > t.idCounter {
+			t.idCounter = todo.ID
+		}
+	}
+	t.recordLoadedStateLocked(info, data)
+	t.changed = true
+	fmt.Println("Reconciled external edits.")
+	return nil
+}
+
+// AutoSave periodically saves the todos to a file if there are changes, and,
+// if syncer is non-nil, synchronizes with the CalDAV server on the same tick.
+func (t *TodoList) AutoSave(filename string, interval time.Duration, done chan bool, syncer *CalDAVSyncer) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.mu.Lock()
+			shouldSave := t.changed
+			t.mu.Unlock()
+
+			// Only reload out-of-band changes when there's nothing pending
+			// locally, so we never clobber an in-progress edit.
+			if !shouldSave {
+				if err := t.Reload(filename); err != nil {
+					fmt.Println("Error reloading file:", err)
+				}
+			}
+
+			if shouldSave {
+				err := t.SaveToFile(filename)
+				if err != nil {
+					if errors.Is(err, ErrListChangedOnDisk) {
+						fmt.Println("To-Do list changed on disk; skipping auto-save until reloaded.")
+					} else {
+						fmt.Println("Error saving file:", err)
+					}
+				}
+			}
+
+			if syncer != nil {
+				if err := syncer.Sync(context.Background(), t); err != nil {
+					fmt.Println("Error syncing with CalDAV:", err)
+				}
+			}
+		case <-done:
+			fmt.Println("Auto-save stopped.")
+			return
+		}
+	}
+}
+
+// FilterByContext returns the todos tagged with the given @context.
+func (t *TodoList) FilterByContext(ctxName string) []Todo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var result []Todo
+	for _, todo := range t.todos {
+		if containsString(todo.Contexts, ctxName) {
+			result = append(result, todo)
+		}
+	}
+	return result
+}
+
+// FilterByProject returns the todos tagged with the given +project.
+func (t *TodoList) FilterByProject(project string) []Todo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var result []Todo
+	for _, todo := range t.todos {
+		if containsString(todo.Projects, project) {
+			result = append(result, todo)
+		}
+	}
+	return result
+}
+
+// FilterByTag returns the todos whose Tags map contains key mapped to value.
+func (t *TodoList) FilterByTag(key, value string) []Todo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var result []Todo
+	for _, todo := range t.todos {
+		if v, ok := todo.Tags[key]; ok && v == value {
+			result = append(result, todo)
+		}
+	}
+	return result
+}
+
+// AllTodos returns a copy of every todo in the list.
+func (t *TodoList) AllTodos() []Todo {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	result := make([]Todo, len(t.todos))
+	copy(result, t.todos)
+	return result
+}
+
+// NextDue returns the next due date id's recurrence rule would produce after
+// its current due date, without mutating the list. It returns the zero time
+// if id doesn't exist, isn't recurring, has no due date, or its Recurrence
+// doesn't parse.
+func (t *TodoList) NextDue(id int) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, todo := range t.todos {
+		if todo.ID != id {
+			continue
+		}
+		if todo.Recurrence == "" || todo.DueDate == nil {
+			return time.Time{}
+		}
+		rule, err := ParseRRule(todo.Recurrence)
+		if err != nil {
+			return time.Time{}
+		}
+		return rule.Next(*todo.DueDate)
+	}
+	return time.Time{}
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
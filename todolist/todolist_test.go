@@ -0,0 +1,125 @@
+package todolist
+
+import "testing"
+
+// completeAndFetch marks id completed via UpdateTodo and returns the
+// resulting list, for asserting on recurrence rollover.
+func completeAndFetch(t *testing.T, list *TodoList, id int) []Todo {
+	t.Helper()
+	list.UpdateTodo(id, "", true)
+	return list.AllTodos()
+}
+
+func TestSpawnNextOccurrenceRespectsCount(t *testing.T) {
+	list := &TodoList{}
+	id := list.CreateTodo("Water plants")
+	due := mustParseDate(t, "2026-01-01")
+	if err := list.SetPriorityAndDueDate(id, "", &due); err != nil {
+		t.Fatalf("SetPriorityAndDueDate: %v", err)
+	}
+	list.mu.Lock()
+	for i := range list.todos {
+		if list.todos[i].ID == id {
+			list.todos[i].Recurrence = "FREQ=DAILY;COUNT=2"
+			list.todos[i].RecurrenceN = 1
+		}
+	}
+	list.mu.Unlock()
+
+	todos := completeAndFetch(t, list, id)
+	if len(todos) != 1 {
+		t.Fatalf("expected no new occurrence once COUNT is reached, got %d todos: %+v", len(todos), todos)
+	}
+}
+
+func TestSpawnNextOccurrenceBeforeCount(t *testing.T) {
+	list := &TodoList{}
+	id := list.CreateTodo("Water plants")
+	due := mustParseDate(t, "2026-01-01")
+	if err := list.SetPriorityAndDueDate(id, "", &due); err != nil {
+		t.Fatalf("SetPriorityAndDueDate: %v", err)
+	}
+	list.mu.Lock()
+	for i := range list.todos {
+		if list.todos[i].ID == id {
+			list.todos[i].Recurrence = "FREQ=DAILY;COUNT=3"
+		}
+	}
+	list.mu.Unlock()
+
+	todos := completeAndFetch(t, list, id)
+	if len(todos) != 2 {
+		t.Fatalf("expected a new occurrence to be spawned, got %d todos: %+v", len(todos), todos)
+	}
+	var next *Todo
+	for i := range todos {
+		if todos[i].ID != id {
+			next = &todos[i]
+		}
+	}
+	if next == nil {
+		t.Fatal("expected a spawned occurrence with a new ID")
+	}
+	if next.RecurrenceN != 1 {
+		t.Errorf("RecurrenceN = %d, want 1", next.RecurrenceN)
+	}
+	want := mustParseDate(t, "2026-01-02")
+	if next.DueDate == nil || !next.DueDate.Equal(want) {
+		t.Errorf("next DueDate = %v, want %v", next.DueDate, want)
+	}
+}
+
+func TestSpawnNextOccurrenceRespectsUntil(t *testing.T) {
+	list := &TodoList{}
+	id := list.CreateTodo("Water plants")
+	due := mustParseDate(t, "2026-01-01")
+	if err := list.SetPriorityAndDueDate(id, "", &due); err != nil {
+		t.Fatalf("SetPriorityAndDueDate: %v", err)
+	}
+	list.mu.Lock()
+	for i := range list.todos {
+		if list.todos[i].ID == id {
+			list.todos[i].Recurrence = "FREQ=DAILY;UNTIL=20260101"
+		}
+	}
+	list.mu.Unlock()
+
+	todos := completeAndFetch(t, list, id)
+	if len(todos) != 1 {
+		t.Fatalf("expected no new occurrence once UNTIL has passed, got %d todos: %+v", len(todos), todos)
+	}
+}
+
+func TestSpawnNextOccurrencePreservesFields(t *testing.T) {
+	list := &TodoList{}
+	id := list.CreateTodo("Water plants")
+	due := mustParseDate(t, "2026-01-01")
+	if err := list.SetPriorityAndDueDate(id, "A", &due); err != nil {
+		t.Fatalf("SetPriorityAndDueDate: %v", err)
+	}
+	list.mu.Lock()
+	for i := range list.todos {
+		if list.todos[i].ID == id {
+			list.todos[i].Recurrence = "FREQ=DAILY"
+			list.todos[i].Tags = map[string]string{"rec": "daily"}
+		}
+	}
+	list.mu.Unlock()
+
+	todos := completeAndFetch(t, list, id)
+	var next *Todo
+	for i := range todos {
+		if todos[i].ID != id {
+			next = &todos[i]
+		}
+	}
+	if next == nil {
+		t.Fatal("expected a spawned occurrence")
+	}
+	if next.Priority != "A" {
+		t.Errorf("spawned occurrence Priority = %q, want %q", next.Priority, "A")
+	}
+	if next.Tags["rec"] != "daily" {
+		t.Errorf("spawned occurrence Tags = %+v, want rec=daily", next.Tags)
+	}
+}
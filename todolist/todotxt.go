@@ -0,0 +1,184 @@
+package todolist
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TodoTxtDateFormat is the date layout used by the todo.txt format (creation,
+// completion, and "due:" dates).
+const TodoTxtDateFormat = "2006-01-02"
+
+// LoadFromTodoTxt loads todos from a file in the todo.txt plain-text format,
+// replacing the in-memory list. Each line looks like:
+//
+//	x (A) 2024-05-01 2024-04-30 Buy milk +groceries @store due:2024-05-05
+//
+// where "x " marks completion, the date following it is the completion date,
+// and an optional date after that is the creation date.
+func (t *TodoList) LoadFromTodoTxt(filename string) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var todos []Todo
+	idCounter := 0
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		idCounter++
+		todo := parseTodoTxtLine(line)
+		todo.ID = idCounter
+		todos = append(todos, todo)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.todos = todos
+	t.idCounter = idCounter
+	fmt.Fprintln(os.Stderr, "To-Do list loaded from todo.txt file.")
+	return nil
+}
+
+// parseTodoTxtLine parses a single todo.txt line into a Todo.
+func parseTodoTxtLine(line string) Todo {
+	todo := Todo{CreatedAt: time.Now()}
+
+	if strings.HasPrefix(line, "x ") {
+		todo.Completed = true
+		line = strings.TrimSpace(line[2:])
+	}
+
+	if len(line) >= 4 && line[0] == '(' && line[2] == ')' && line[1] >= 'A' && line[1] <= 'Z' && line[3] == ' ' {
+		todo.Priority = string(line[1])
+		line = strings.TrimSpace(line[4:])
+	}
+
+	if todo.Completed {
+		if d, rest, ok := cutTodoTxtDate(line); ok {
+			todo.CompletedAt = &d
+			line = rest
+			if d2, rest2, ok := cutTodoTxtDate(line); ok {
+				todo.CreatedAt = d2
+				line = rest2
+			}
+		}
+	} else if d, rest, ok := cutTodoTxtDate(line); ok {
+		todo.CreatedAt = d
+		line = rest
+	}
+
+	var titleWords []string
+	tags := map[string]string{}
+	for _, word := range strings.Fields(line) {
+		switch {
+		case strings.HasPrefix(word, "+") && len(word) > 1:
+			todo.Projects = append(todo.Projects, word[1:])
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			todo.Contexts = append(todo.Contexts, word[1:])
+		case strings.Contains(word, ":"):
+			kv := strings.SplitN(word, ":", 2)
+			if kv[0] == "due" {
+				if due, err := time.Parse(TodoTxtDateFormat, kv[1]); err == nil {
+					todo.DueDate = &due
+					continue
+				}
+			}
+			tags[kv[0]] = kv[1]
+		default:
+			titleWords = append(titleWords, word)
+		}
+	}
+	if len(tags) > 0 {
+		todo.Tags = tags
+	}
+	todo.Title = strings.Join(titleWords, " ")
+	return todo
+}
+
+// cutTodoTxtDate parses a leading "YYYY-MM-DD " token from line, if present.
+func cutTodoTxtDate(line string) (time.Time, string, bool) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return time.Time{}, line, false
+	}
+	d, err := time.Parse(TodoTxtDateFormat, fields[0])
+	if err != nil {
+		return time.Time{}, line, false
+	}
+	rest := strings.TrimPrefix(line, fields[0])
+	return d, strings.TrimSpace(rest), true
+}
+
+// SaveToTodoTxt writes the todos to a file in the todo.txt plain-text format.
+func (t *TodoList) SaveToTodoTxt(filename string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	for _, todo := range t.todos {
+		if _, err := writer.WriteString(formatTodoTxtLine(todo) + "\n"); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "To-Do list saved to todo.txt file.")
+	return nil
+}
+
+// formatTodoTxtLine renders a Todo as a single todo.txt line.
+func formatTodoTxtLine(todo Todo) string {
+	var b strings.Builder
+	if todo.Completed {
+		b.WriteString("x ")
+	}
+	if todo.Priority != "" {
+		b.WriteString("(" + todo.Priority + ") ")
+	}
+	if todo.Completed && todo.CompletedAt != nil {
+		b.WriteString(todo.CompletedAt.Format(TodoTxtDateFormat) + " ")
+	}
+	if !todo.CreatedAt.IsZero() {
+		b.WriteString(todo.CreatedAt.Format(TodoTxtDateFormat) + " ")
+	}
+	b.WriteString(todo.Title)
+	for _, project := range todo.Projects {
+		b.WriteString(" +" + project)
+	}
+	for _, ctxName := range todo.Contexts {
+		b.WriteString(" @" + ctxName)
+	}
+	if todo.DueDate != nil {
+		b.WriteString(" due:" + todo.DueDate.Format(TodoTxtDateFormat))
+	}
+	keys := make([]string, 0, len(todo.Tags))
+	for k := range todo.Tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(" " + k + ":" + todo.Tags[k])
+	}
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package todolist
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseTodoTxtLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want Todo
+	}{
+		{
+			name: "plain title",
+			line: "Buy milk",
+			want: Todo{Title: "Buy milk"},
+		},
+		{
+			name: "priority and projects and contexts",
+			line: "(A) Buy milk +groceries @store",
+			want: Todo{
+				Title:    "Buy milk",
+				Priority: "A",
+				Projects: []string{"groceries"},
+				Contexts: []string{"store"},
+			},
+		},
+		{
+			name: "due date tag",
+			line: "Buy milk due:2026-05-05",
+			want: Todo{Title: "Buy milk", DueDate: dateP(t, "2026-05-05")},
+		},
+		{
+			name: "arbitrary key value tag",
+			line: "Buy milk rec:weekly",
+			want: Todo{Title: "Buy milk", Tags: map[string]string{"rec": "weekly"}},
+		},
+		{
+			name: "completed with completion and creation dates",
+			line: "x 2026-05-02 2026-05-01 Buy milk",
+			want: Todo{
+				Title:       "Buy milk",
+				Completed:   true,
+				CompletedAt: dateP(t, "2026-05-02"),
+				CreatedAt:   mustParseDate(t, "2026-05-01"),
+			},
+		},
+		{
+			name: "completed without dates",
+			line: "x Buy milk",
+			want: Todo{Title: "Buy milk", Completed: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseTodoTxtLine(tt.line)
+			// CreatedAt defaults to time.Now() when absent from the line;
+			// only compare it when the test case cares about an exact value.
+			if tt.want.CreatedAt.IsZero() {
+				got.CreatedAt = time.Time{}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTodoTxtLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTodoTxtLine(t *testing.T) {
+	due := dateP(t, "2026-05-05")
+	completedAt := dateP(t, "2026-05-02")
+	createdAt := mustParseDate(t, "2026-05-01")
+
+	todo := Todo{
+		Title:       "Buy milk",
+		Priority:    "A",
+		Projects:    []string{"groceries"},
+		Contexts:    []string{"store"},
+		Tags:        map[string]string{"rec": "weekly"},
+		DueDate:     due,
+		Completed:   true,
+		CompletedAt: completedAt,
+		CreatedAt:   createdAt,
+	}
+
+	got := formatTodoTxtLine(todo)
+	want := "x (A) 2026-05-02 2026-05-01 Buy milk +groceries @store due:2026-05-05 rec:weekly"
+	if got != want {
+		t.Errorf("formatTodoTxtLine() = %q, want %q", got, want)
+	}
+}
+
+func TestTodoTxtRoundTrip(t *testing.T) {
+	original := Todo{
+		Title:     "Buy milk",
+		Priority:  "B",
+		Projects:  []string{"groceries"},
+		Contexts:  []string{"store"},
+		DueDate:   dateP(t, "2026-05-05"),
+		CreatedAt: mustParseDate(t, "2026-05-01"),
+	}
+
+	line := formatTodoTxtLine(original)
+	parsed := parseTodoTxtLine(line)
+
+	if parsed.Title != original.Title || parsed.Priority != original.Priority {
+		t.Errorf("round trip changed Title/Priority: got %+v, original %+v", parsed, original)
+	}
+	if !reflect.DeepEqual(parsed.Projects, original.Projects) || !reflect.DeepEqual(parsed.Contexts, original.Contexts) {
+		t.Errorf("round trip changed Projects/Contexts: got %+v, original %+v", parsed, original)
+	}
+	if !parsed.DueDate.Equal(*original.DueDate) {
+		t.Errorf("round trip changed DueDate: got %v, original %v", parsed.DueDate, original.DueDate)
+	}
+}
+
+func TestCutTodoTxtDate(t *testing.T) {
+	d, rest, ok := cutTodoTxtDate("2026-05-05 Buy milk")
+	if !ok {
+		t.Fatal("cutTodoTxtDate: expected ok=true")
+	}
+	if !d.Equal(mustParseDate(t, "2026-05-05")) {
+		t.Errorf("cutTodoTxtDate date = %v, want 2026-05-05", d)
+	}
+	if rest != "Buy milk" {
+		t.Errorf("cutTodoTxtDate rest = %q, want %q", rest, "Buy milk")
+	}
+
+	_, _, ok = cutTodoTxtDate("Buy milk")
+	if ok {
+		t.Error("cutTodoTxtDate: expected ok=false for a line with no leading date")
+	}
+}
+
+func dateP(t *testing.T, s string) *time.Time {
+	t.Helper()
+	d := mustParseDate(t, s)
+	return &d
+}